@@ -0,0 +1,230 @@
+package bridge
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Codec encodes and decodes a Go type to and from the wire payload carried in a
+// pb.Value_Typed, so bridges can round-trip domain types (big numbers, times, UUIDs, ...)
+// without losing precision to the generic JSON fallback in encodeValue/decodeValue.
+type Codec interface {
+	// Type is the wire type tag stored alongside the payload, e.g. "time.Time". It must
+	// be unique among the codecs registered on a given ServeOptions.
+	Type() string
+	// Matches reports whether this codec should encode value.
+	Matches(value any) bool
+	// Encode renders value as the wire payload for this codec's Type.
+	Encode(value any) (string, error)
+	// Decode parses a payload produced by Encode back into a Go value.
+	Decode(payload string) (any, error)
+}
+
+// codecRegistry resolves codecs by Go value (for encoding) or by wire type tag (for
+// decoding). Custom codecs take priority over the built-ins, so a bridge can override
+// the default representation of a type it cares about.
+type codecRegistry struct {
+	codecs []Codec
+	byType map[string]Codec
+}
+
+func newCodecRegistry(custom []Codec) *codecRegistry {
+	all := make([]Codec, 0, len(custom)+len(builtinCodecs))
+	all = append(all, custom...)
+	all = append(all, builtinCodecs...)
+
+	// Build byType with builtins first so a custom codec's Type() always wins a
+	// collision, matching forValue's custom-first precedence for encoding.
+	byType := make(map[string]Codec, len(all))
+	for _, codec := range builtinCodecs {
+		byType[codec.Type()] = codec
+	}
+	for _, codec := range custom {
+		byType[codec.Type()] = codec
+	}
+	return &codecRegistry{codecs: all, byType: byType}
+}
+
+func (r *codecRegistry) forValue(value any) (Codec, bool) {
+	if r == nil {
+		return nil, false
+	}
+	for _, codec := range r.codecs {
+		if codec.Matches(value) {
+			return codec, true
+		}
+	}
+	return nil, false
+}
+
+func (r *codecRegistry) forType(typeTag string) (Codec, bool) {
+	if r == nil {
+		return nil, false
+	}
+	codec, ok := r.byType[typeTag]
+	return codec, ok
+}
+
+var builtinCodecs = []Codec{
+	timeCodec{},
+	durationCodec{},
+	bigIntCodec{},
+	bigFloatCodec{},
+	uuidCodec{},
+	netipAddrCodec{},
+}
+
+type timeCodec struct{}
+
+func (timeCodec) Type() string { return "time.Time" }
+
+func (timeCodec) Matches(value any) bool {
+	_, ok := value.(time.Time)
+	return ok
+}
+
+func (timeCodec) Encode(value any) (string, error) {
+	t, ok := value.(time.Time)
+	if !ok {
+		return "", fmt.Errorf("timeCodec: want time.Time, got %T", value)
+	}
+	return t.Round(0).UTC().Format(time.RFC3339Nano), nil
+}
+
+func (timeCodec) Decode(payload string) (any, error) {
+	return time.Parse(time.RFC3339Nano, payload)
+}
+
+type durationCodec struct{}
+
+func (durationCodec) Type() string { return "time.Duration" }
+
+func (durationCodec) Matches(value any) bool {
+	_, ok := value.(time.Duration)
+	return ok
+}
+
+func (durationCodec) Encode(value any) (string, error) {
+	d, ok := value.(time.Duration)
+	if !ok {
+		return "", fmt.Errorf("durationCodec: want time.Duration, got %T", value)
+	}
+	return fmt.Sprintf("%d", int64(d)), nil
+}
+
+func (durationCodec) Decode(payload string) (any, error) {
+	var ns int64
+	if _, err := fmt.Sscanf(payload, "%d", &ns); err != nil {
+		return nil, fmt.Errorf("durationCodec: parse %q: %w", payload, err)
+	}
+	return time.Duration(ns), nil
+}
+
+type bigIntCodec struct{}
+
+func (bigIntCodec) Type() string { return "big.Int" }
+
+func (bigIntCodec) Matches(value any) bool {
+	_, ok := value.(*big.Int)
+	return ok
+}
+
+func (bigIntCodec) Encode(value any) (string, error) {
+	v, ok := value.(*big.Int)
+	if !ok || v == nil {
+		return "", fmt.Errorf("bigIntCodec: want non-nil *big.Int, got %T", value)
+	}
+	return v.String(), nil
+}
+
+func (bigIntCodec) Decode(payload string) (any, error) {
+	v, ok := new(big.Int).SetString(payload, 10)
+	if !ok {
+		return nil, fmt.Errorf("bigIntCodec: invalid payload %q", payload)
+	}
+	return v, nil
+}
+
+type bigFloatCodec struct{}
+
+func (bigFloatCodec) Type() string { return "big.Float" }
+
+func (bigFloatCodec) Matches(value any) bool {
+	_, ok := value.(*big.Float)
+	return ok
+}
+
+// Encode carries the original precision alongside the text so Decode can restore it -
+// otherwise ParseFloat's default 64-bit precision would quietly truncate any *big.Float
+// built with a wider mantissa, defeating the point of using big.Float over float64.
+func (bigFloatCodec) Encode(value any) (string, error) {
+	v, ok := value.(*big.Float)
+	if !ok || v == nil {
+		return "", fmt.Errorf("bigFloatCodec: want non-nil *big.Float, got %T", value)
+	}
+	return fmt.Sprintf("%d:%s", v.Prec(), v.Text('g', -1)), nil
+}
+
+func (bigFloatCodec) Decode(payload string) (any, error) {
+	precStr, text, ok := strings.Cut(payload, ":")
+	if !ok {
+		return nil, fmt.Errorf("bigFloatCodec: invalid payload %q", payload)
+	}
+	prec, err := strconv.ParseUint(precStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("bigFloatCodec: invalid precision in payload %q: %w", payload, err)
+	}
+	v, _, err := big.ParseFloat(text, 10, uint(prec), big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("bigFloatCodec: invalid payload %q: %w", payload, err)
+	}
+	return v, nil
+}
+
+type uuidCodec struct{}
+
+func (uuidCodec) Type() string { return "uuid.UUID" }
+
+func (uuidCodec) Matches(value any) bool {
+	_, ok := value.(uuid.UUID)
+	return ok
+}
+
+func (uuidCodec) Encode(value any) (string, error) {
+	v, ok := value.(uuid.UUID)
+	if !ok {
+		return "", fmt.Errorf("uuidCodec: want uuid.UUID, got %T", value)
+	}
+	return v.String(), nil
+}
+
+func (uuidCodec) Decode(payload string) (any, error) {
+	return uuid.Parse(payload)
+}
+
+type netipAddrCodec struct{}
+
+func (netipAddrCodec) Type() string { return "netip.Addr" }
+
+func (netipAddrCodec) Matches(value any) bool {
+	_, ok := value.(netip.Addr)
+	return ok
+}
+
+func (netipAddrCodec) Encode(value any) (string, error) {
+	v, ok := value.(netip.Addr)
+	if !ok {
+		return "", fmt.Errorf("netipAddrCodec: want netip.Addr, got %T", value)
+	}
+	return v.String(), nil
+}
+
+func (netipAddrCodec) Decode(payload string) (any, error) {
+	return netip.ParseAddr(payload)
+}
@@ -0,0 +1,143 @@
+package bridge
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// stubCodec is a trivial Codec used to probe registry precedence without pulling in a
+// real domain type.
+type stubCodec struct {
+	typ     string
+	matches func(value any) bool
+	payload string
+}
+
+func (s stubCodec) Type() string               { return s.typ }
+func (s stubCodec) Matches(value any) bool      { return s.matches(value) }
+func (s stubCodec) Encode(value any) (string, error) { return s.payload, nil }
+func (s stubCodec) Decode(payload string) (any, error) {
+	return "decoded:" + s.payload + ":" + payload, nil
+}
+
+// TestCodecRegistry_CustomCodecPrecedence guards against the byType-collision
+// regression fixed in 76c07e5: a custom codec overriding a built-in type tag (here
+// "time.Time") must win both encoding (forValue, list order) and decoding
+// (forType/byType), not just one of the two.
+func TestCodecRegistry_CustomCodecPrecedence(t *testing.T) {
+	custom := stubCodec{
+		typ:     "time.Time",
+		matches: func(value any) bool { _, ok := value.(time.Time); return ok },
+		payload: "custom-payload",
+	}
+	registry := newCodecRegistry([]Codec{custom})
+
+	codec, ok := registry.forValue(time.Now())
+	if !ok || codec.Type() != "time.Time" {
+		t.Fatalf("forValue: want custom codec, got %#v, %v", codec, ok)
+	}
+	if _, isCustom := codec.(stubCodec); !isCustom {
+		t.Fatalf("forValue resolved the built-in timeCodec instead of the custom one")
+	}
+
+	byType, ok := registry.forType("time.Time")
+	if !ok {
+		t.Fatal("forType(\"time.Time\") not found")
+	}
+	if _, isCustom := byType.(stubCodec); !isCustom {
+		t.Fatalf("forType resolved the built-in timeCodec instead of the custom one - encode/decode would use different codecs for the same type tag")
+	}
+}
+
+// TestEncodeValue_OversizedUint64 guards against the uint64-overflow regression fixed
+// in d501541: a native uint64 above math.MaxInt64 must round-trip through the big.Int
+// codec instead of erroring.
+func TestEncodeValue_OversizedUint64(t *testing.T) {
+	codecs := newCodecRegistry(nil)
+	var u uint64 = math.MaxInt64 + 100
+
+	msg, err := encodeValue(u, codecs)
+	if err != nil {
+		t.Fatalf("encodeValue(%d): %v", u, err)
+	}
+
+	decoded, err := decodeValue(msg, codecs)
+	if err != nil {
+		t.Fatalf("decodeValue: %v", err)
+	}
+
+	got, ok := decoded.(*big.Int)
+	if !ok {
+		t.Fatalf("decodeValue returned %T, want *big.Int", decoded)
+	}
+	want := new(big.Int).SetUint64(u)
+	if got.Cmp(want) != 0 {
+		t.Fatalf("round-tripped value = %s, want %s", got, want)
+	}
+}
+
+// TestBigFloatCodec_PrecisionRoundTrip guards against the precision-truncation
+// regression fixed in 545e36a: a *big.Float built with more than 64 bits of mantissa
+// must not lose precision across Encode/Decode.
+func TestBigFloatCodec_PrecisionRoundTrip(t *testing.T) {
+	const prec = 200
+	v := new(big.Float).SetPrec(prec)
+	if _, _, err := v.Parse("1.00000000000000000000000000000000000000000001", 10); err != nil {
+		t.Fatalf("parse fixture value: %v", err)
+	}
+
+	codec := bigFloatCodec{}
+	payload, err := codec.Encode(v)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	decoded, err := codec.Decode(payload)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	got, ok := decoded.(*big.Float)
+	if !ok {
+		t.Fatalf("Decode returned %T, want *big.Float", decoded)
+	}
+	if got.Prec() != prec {
+		t.Fatalf("decoded precision = %d, want %d", got.Prec(), prec)
+	}
+	if got.Cmp(v) != 0 {
+		t.Fatalf("decoded value = %s, want %s", got.Text('g', -1), v.Text('g', -1))
+	}
+}
+
+func TestBigIntCodec_RoundTrip(t *testing.T) {
+	v, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	if !ok {
+		t.Fatal("failed to build fixture big.Int")
+	}
+
+	codec := bigIntCodec{}
+	payload, err := codec.Encode(v)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := codec.Decode(payload)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	got, ok := decoded.(*big.Int)
+	if !ok || got.Cmp(v) != 0 {
+		t.Fatalf("round-tripped value = %v, want %v", decoded, v)
+	}
+}
+
+func Example_bigFloatCodecPayloadFormat() {
+	codec := bigFloatCodec{}
+	v := new(big.Float).SetPrec(64)
+	v.SetFloat64(1.5)
+	payload, _ := codec.Encode(v)
+	fmt.Println(payload)
+	// Output: 64:1.5
+}
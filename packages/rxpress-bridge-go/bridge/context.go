@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"google.golang.org/grpc/metadata"
+	"go.opentelemetry.io/otel/trace"
+
 	pb "github.com/newintel/rxpress-bridge-go/internal/pb/proto"
 )
 
@@ -12,17 +15,23 @@ type Context struct {
 	control *controlPlane
 	meta    map[string]any
 	runID   string
+	codecs  *codecRegistry
+	// invokeCtx is the invocation's own ctx (carrying its bridge.invoke/{handler} span),
+	// used to parent Log's control-plane span since Log takes no ctx of its own.
+	invokeCtx context.Context
 }
 
-func newContext(control *controlPlane, meta map[string]any) *Context {
-	ctx := &Context{
-		control: control,
-		meta:    meta,
+func newContext(ctx context.Context, control *controlPlane, meta map[string]any) *Context {
+	bridgeCtx := &Context{
+		control:   control,
+		meta:      meta,
+		codecs:    control.codecs,
+		invokeCtx: ctx,
 	}
 	if v, ok := meta["run_id"].(string); ok {
-		ctx.runID = v
+		bridgeCtx.runID = v
 	}
-	return ctx
+	return bridgeCtx
 }
 
 func (c *Context) Log(level, message string, fields map[string]any) error {
@@ -35,37 +44,65 @@ func (c *Context) Log(level, message string, fields map[string]any) error {
 			payload["runId"] = c.runID
 		}
 	}
-	encoded, err := encodeMap(payload)
+	encoded, err := encodeMap(payload, c.codecs)
 	if err != nil {
 		return err
 	}
-	return c.control.log(toProtoMeta(c.meta), level, message, encoded)
+	return c.control.log(c.invokeCtx, toProtoMeta(c.meta), level, message, encoded)
 }
 
-func (c *Context) Emit(_ context.Context, topic string, data map[string]any) error {
-	encoded, err := encodeMap(data)
+func (c *Context) Emit(ctx context.Context, topic string, data map[string]any) error {
+	encoded, err := encodeMap(data, c.codecs)
 	if err != nil {
 		return err
 	}
-	return c.control.emit(toProtoMeta(c.meta), topic, encoded)
+	return c.control.emit(ctx, toProtoMeta(c.meta), topic, encoded)
+}
+
+func (c *Context) KVGet(ctx context.Context, bucket, key string) (any, error) {
+	return c.control.kvGet(ctx, bucket, key)
 }
 
-func (c *Context) KVGet(_ context.Context, bucket, key string) (any, error) {
-	return c.control.kvGet(bucket, key)
+func (c *Context) KVPut(ctx context.Context, bucket, key string, value any, ttl time.Duration) error {
+	return c.control.kvPut(ctx, bucket, key, value, ttl)
 }
 
-func (c *Context) KVPut(_ context.Context, bucket, key string, value any, ttl time.Duration) error {
-	return c.control.kvPut(bucket, key, value, ttl)
+func (c *Context) KVDel(ctx context.Context, bucket, key string) error {
+	return c.control.kvDel(ctx, bucket, key)
 }
 
-func (c *Context) KVDel(_ context.Context, bucket, key string) error {
-	return c.control.kvDel(bucket, key)
+// Tracer returns the OTel tracer used for this invocation's spans, so handlers can
+// start their own child spans that nest correctly under bridge.invoke/{handler}.
+func (c *Context) Tracer() trace.Tracer {
+	return c.control.telemetry.tracer
 }
 
-func encodeMap(values map[string]any) (map[string]*pb.Value, error) {
+// OutgoingContext returns ctx carrying the invocation's trace/span/baggage as outbound
+// gRPC metadata, for handlers that make their own downstream RPCs and need the call
+// chain to stay correlated.
+func (c *Context) OutgoingContext(ctx context.Context) context.Context {
+	md := metadata.MD{}
+	if traceID, _ := c.meta["trace_id"].(string); traceID != "" {
+		md.Set("trace-id", traceID)
+	}
+	if spanID, _ := c.meta["span_id"].(string); spanID != "" {
+		md.Set("span-id", spanID)
+	}
+	if baggage, ok := c.meta["baggage"].(map[string]any); ok {
+		for k, v := range baggage {
+			md.Set("baggage-"+k, fmt.Sprint(v))
+		}
+	}
+	if len(md) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func encodeMap(values map[string]any, codecs *codecRegistry) (map[string]*pb.Value, error) {
 	result := make(map[string]*pb.Value, len(values))
 	for k, v := range values {
-		encoded, err := encodeValue(v)
+		encoded, err := encodeValue(v, codecs)
 		if err != nil {
 			return nil, fmt.Errorf("encode map value %q: %w", k, err)
 		}
@@ -108,6 +145,12 @@ func fromProtoMeta(meta *pb.Meta) map[string]any {
 		"span_id":  meta.GetSpanId(),
 		"tenant":   meta.GetTenant(),
 	}
+	if ms := meta.GetDeadlineMs(); ms > 0 {
+		result["deadline_ms"] = ms
+	}
+	if ms := meta.GetCancelAfterMs(); ms > 0 {
+		result["cancel_after_ms"] = ms
+	}
 	if baggage := meta.GetBaggage(); len(baggage) > 0 {
 		copy := make(map[string]any, len(baggage))
 		for k, v := range baggage {
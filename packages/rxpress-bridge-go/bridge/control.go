@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,55 +17,159 @@ import (
 	pb "github.com/newintel/rxpress-bridge-go/internal/pb/proto"
 )
 
+// ErrControlDisconnected is returned by non-idempotent control-plane calls (currently
+// only Emit) when the stream drops before a reply arrives. Idempotent calls (Log, KV
+// get/put/delete) are transparently replayed against the reconnected stream instead.
+var ErrControlDisconnected = errors.New("bridge: control plane disconnected")
+
+// ControlState describes the lifecycle of a controlPlane's connection to the control
+// plane server.
+type ControlState int32
+
+const (
+	ControlConnected ControlState = iota
+	ControlReconnecting
+	ControlFailed
+)
+
+func (s ControlState) String() string {
+	switch s {
+	case ControlConnected:
+		return "connected"
+	case ControlReconnecting:
+		return "reconnecting"
+	case ControlFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ControlBackoff configures the exponential backoff used between reconnect attempts.
+// Base and Max default to 200ms and 30s respectively when left zero.
+type ControlBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b ControlBackoff) withDefaults() ControlBackoff {
+	if b.Base <= 0 {
+		b.Base = 200 * time.Millisecond
+	}
+	if b.Max <= 0 {
+		b.Max = 30 * time.Second
+	}
+	return b
+}
+
 type ctrlResult struct {
 	msg *pb.Control
 	err error
 }
 
+type pendingEntry struct {
+	ch         chan ctrlResult
+	idempotent bool
+	req        *pb.Control
+}
+
+// controlDialer dials a fresh connection to the control plane and opens its Connect
+// stream; it is called once up front and again on every reconnect attempt.
+type controlDialer func(ctx context.Context) (*grpc.ClientConn, pb.ControlPlane_ConnectClient, error)
+
 type controlPlane struct {
+	dial      controlDialer
+	backoff   ControlBackoff
+	codecs    *codecRegistry
+	ready     *readinessMonitor
+	telemetry *telemetry
+
+	baseCtx context.Context
+	cancel  context.CancelFunc
+
+	connMu sync.RWMutex
+	conn   *grpc.ClientConn
 	stream pb.ControlPlane_ConnectClient
-	cancel context.CancelFunc
 
 	sendMu  sync.Mutex
-	pending sync.Map // map[string]chan ctrlResult
+	pending sync.Map // map[string]*pendingEntry
+
+	state  int32 // atomic ControlState
+	closed int32
 
 	wg sync.WaitGroup
 }
 
-func newControlPlane(ctx context.Context, conn *grpc.ClientConn) (*controlPlane, error) {
-	stub := pb.NewControlPlaneClient(conn)
-	ctx, cancel := context.WithCancel(ctx)
-	stream, err := stub.Connect(ctx)
+func newControlPlane(ctx context.Context, dial controlDialer, codecs *codecRegistry, backoff ControlBackoff, ready *readinessMonitor, telemetry *telemetry) (*controlPlane, error) {
+	baseCtx, cancel := context.WithCancel(ctx)
+
+	conn, stream, err := dial(baseCtx)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("control connect: %w", err)
 	}
 
 	cp := &controlPlane{
-		stream: stream,
-		cancel: cancel,
+		dial:      dial,
+		backoff:   backoff.withDefaults(),
+		codecs:    codecs,
+		ready:     ready,
+		telemetry: telemetry,
+		baseCtx:   baseCtx,
+		cancel:    cancel,
+		conn:      conn,
+		stream:    stream,
 	}
+	cp.setState(ControlConnected)
 
 	cp.wg.Add(1)
 	go cp.recvLoop()
 	return cp, nil
 }
 
+// State reports the controlPlane's current connectivity, for readiness checks and
+// diagnostics.
+func (c *controlPlane) State() ControlState {
+	return ControlState(atomic.LoadInt32(&c.state))
+}
+
+func (c *controlPlane) setState(state ControlState) {
+	atomic.StoreInt32(&c.state, int32(state))
+	if c.ready != nil {
+		c.ready.setControl(state == ControlConnected)
+	}
+}
+
+func (c *controlPlane) currentStream() pb.ControlPlane_ConnectClient {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.stream
+}
+
 func (c *controlPlane) recvLoop() {
 	defer c.wg.Done()
 	for {
-		msg, err := c.stream.Recv()
+		stream := c.currentStream()
+		msg, err := stream.Recv()
 		if err != nil {
-			c.failAll(err)
-			return
+			if atomic.LoadInt32(&c.closed) == 1 {
+				return
+			}
+			c.failAll()
+			if !c.reconnect() {
+				c.setState(ControlFailed)
+				return
+			}
+			continue
 		}
 		if msg == nil {
 			continue
 		}
 		if corr := msg.GetCorrelation(); corr != "" {
-			if ch, ok := c.pending.LoadAndDelete(corr); ok {
+			if value, ok := c.pending.LoadAndDelete(corr); ok {
+				entry := value.(*pendingEntry)
 				select {
-				case ch.(chan ctrlResult) <- ctrlResult{msg: msg}:
+				case entry.ch <- ctrlResult{msg: msg}:
 				default:
 				}
 			}
@@ -71,11 +177,16 @@ func (c *controlPlane) recvLoop() {
 	}
 }
 
-func (c *controlPlane) failAll(err error) {
+// failAll resolves every non-idempotent pending call with ErrControlDisconnected.
+// Idempotent calls are left in place so reconnect can replay them.
+func (c *controlPlane) failAll() {
 	c.pending.Range(func(key, value any) bool {
-		ch := value.(chan ctrlResult)
+		entry := value.(*pendingEntry)
+		if entry.idempotent {
+			return true
+		}
 		select {
-		case ch <- ctrlResult{err: err}:
+		case entry.ch <- ctrlResult{err: ErrControlDisconnected}:
 		default:
 		}
 		c.pending.Delete(key)
@@ -83,12 +194,82 @@ func (c *controlPlane) failAll(err error) {
 	})
 }
 
+// reconnect redials the control plane with exponential backoff and jitter until it
+// succeeds or baseCtx is done. On success it replays any idempotent calls still
+// waiting for a reply.
+func (c *controlPlane) reconnect() bool {
+	c.setState(ControlReconnecting)
+
+	delay := c.backoff.Base
+	for {
+		select {
+		case <-c.baseCtx.Done():
+			return false
+		case <-time.After(jitter(delay)):
+		}
+
+		conn, stream, err := c.dial(c.baseCtx)
+		if err != nil {
+			delay *= 2
+			if delay > c.backoff.Max {
+				delay = c.backoff.Max
+			}
+			continue
+		}
+
+		c.connMu.Lock()
+		old := c.conn
+		c.conn = conn
+		c.stream = stream
+		c.connMu.Unlock()
+		if old != nil {
+			_ = old.Close()
+		}
+
+		c.setState(ControlConnected)
+		c.replayPending()
+		return true
+	}
+}
+
+func (c *controlPlane) replayPending() {
+	c.pending.Range(func(key, value any) bool {
+		entry := value.(*pendingEntry)
+		c.sendMu.Lock()
+		err := c.currentStream().Send(entry.req)
+		c.sendMu.Unlock()
+		if err != nil {
+			select {
+			case entry.ch <- ctrlResult{err: fmt.Errorf("control replay: %w", err)}:
+			default:
+			}
+			c.pending.Delete(key)
+		}
+		return true
+	})
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
 func (c *controlPlane) Close() {
+	atomic.StoreInt32(&c.closed, 1)
 	c.cancel()
 	c.wg.Wait()
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+	if conn != nil {
+		_ = conn.Close()
+	}
 }
 
-func (c *controlPlane) send(control *pb.Control, expectReply bool, timeout time.Duration) (*pb.Control, error) {
+func (c *controlPlane) send(control *pb.Control, expectReply, idempotent bool, timeout time.Duration) (*pb.Control, error) {
 	if control == nil {
 		return nil, errors.New("control message is nil")
 	}
@@ -99,14 +280,14 @@ func (c *controlPlane) send(control *pb.Control, expectReply bool, timeout time.
 		control.Correlation = corr
 	}
 
-	var ch chan ctrlResult
+	var entry *pendingEntry
 	if expectReply {
-		ch = make(chan ctrlResult, 1)
-		c.pending.Store(corr, ch)
+		entry = &pendingEntry{ch: make(chan ctrlResult, 1), idempotent: idempotent, req: control}
+		c.pending.Store(corr, entry)
 	}
 
 	c.sendMu.Lock()
-	err := c.stream.Send(control)
+	err := c.currentStream().Send(control)
 	c.sendMu.Unlock()
 	if err != nil {
 		if expectReply {
@@ -119,20 +300,30 @@ func (c *controlPlane) send(control *pb.Control, expectReply bool, timeout time.
 		return nil, nil
 	}
 
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), timeout)
+	defer waitCancel()
+
 	select {
-	case res := <-ch:
+	case res := <-entry.ch:
 		if res.err != nil {
 			return nil, res.err
 		}
 		return res.msg, nil
-	case <-time.After(timeout):
+	case <-waitCtx.Done():
 		c.pending.Delete(corr)
 		return nil, errors.New("control timeout")
 	}
 }
 
-func (c *controlPlane) log(meta *pb.Meta, level, msg string, fields map[string]*pb.Value) error {
-	_, err := c.send(&pb.Control{
+// log is idempotent like the KV ops - the control plane appends the same line whether it
+// arrives once or twice after a replay - so it waits for an ack and registers a
+// pendingEntry, letting replayPending re-send it if the stream drops before the ack
+// arrives instead of failing the call outright.
+func (c *controlPlane) log(ctx context.Context, meta *pb.Meta, level, msg string, fields map[string]*pb.Value) (err error) {
+	_, endSpan := c.telemetry.controlSpan(ctx, "bridge.control.log")
+	defer func() { endSpan(err) }()
+
+	resp, err := c.send(&pb.Control{
 		Meta: meta,
 		OneofMsg: &pb.Control_Log{
 			Log: &pb.LogReq{
@@ -141,11 +332,31 @@ func (c *controlPlane) log(meta *pb.Meta, level, msg string, fields map[string]*
 				Fields: fields,
 			},
 		},
-	}, false, 0)
+	}, true, true, 5*time.Second)
+	if err != nil {
+		return err
+	}
+
+	if resp == nil {
+		err = errors.New("control log: empty response")
+		return err
+	}
+
+	switch payload := resp.OneofMsg.(type) {
+	case *pb.Control_KvCommonRes:
+		if status := payload.KvCommonRes.GetStatus(); status.GetCode() != 0 {
+			err = statusError(status)
+		}
+	default:
+		err = fmt.Errorf("control log: unexpected response %T", payload)
+	}
 	return err
 }
 
-func (c *controlPlane) emit(meta *pb.Meta, topic string, data map[string]*pb.Value) error {
+func (c *controlPlane) emit(ctx context.Context, meta *pb.Meta, topic string, data map[string]*pb.Value) (err error) {
+	_, endSpan := c.telemetry.controlSpan(ctx, "bridge.control.emit")
+	defer func() { endSpan(err) }()
+
 	resp, err := c.send(&pb.Control{
 		Meta: meta,
 		OneofMsg: &pb.Control_Emit{
@@ -154,27 +365,31 @@ func (c *controlPlane) emit(meta *pb.Meta, topic string, data map[string]*pb.Val
 				Data:  data,
 			},
 		},
-	}, true, 5*time.Second)
+	}, true, false, 5*time.Second)
 	if err != nil {
 		return err
 	}
 
 	if resp == nil {
-		return errors.New("control emit: empty response")
+		err = errors.New("control emit: empty response")
+		return err
 	}
 
 	switch payload := resp.OneofMsg.(type) {
 	case *pb.Control_KvCommonRes:
 		if status := payload.KvCommonRes.GetStatus(); status.GetCode() != 0 {
-			return statusError(status)
+			err = statusError(status)
 		}
-		return nil
 	default:
-		return fmt.Errorf("control emit: unexpected response %T", payload)
+		err = fmt.Errorf("control emit: unexpected response %T", payload)
 	}
+	return err
 }
 
-func (c *controlPlane) kvGet(bucket, key string) (any, error) {
+func (c *controlPlane) kvGet(ctx context.Context, bucket, key string) (_ any, err error) {
+	_, endSpan := c.telemetry.controlSpan(ctx, "bridge.control.kv_get")
+	defer func() { endSpan(err) }()
+
 	resp, err := c.send(&pb.Control{
 		OneofMsg: &pb.Control_KvGet{
 			KvGet: &pb.KVGetReq{
@@ -182,27 +397,32 @@ func (c *controlPlane) kvGet(bucket, key string) (any, error) {
 				Key:    key,
 			},
 		},
-	}, true, 5*time.Second)
+	}, true, true, 5*time.Second)
 	if err != nil {
 		return nil, err
 	}
 
 	res := resp.GetKvGetRes()
 	if res == nil {
-		return nil, errors.New("kv_get: empty response")
+		err = errors.New("kv_get: empty response")
+		return nil, err
 	}
 	if st := res.GetStatus(); st.GetCode() != 0 {
-		return nil, statusError(st)
+		err = statusError(st)
+		return nil, err
 	}
-	value, err := decodeValue(res.GetValue())
+	value, err := decodeValue(res.GetValue(), c.codecs)
 	if err != nil {
 		return nil, err
 	}
 	return value, nil
 }
 
-func (c *controlPlane) kvPut(bucket, key string, value any, ttl time.Duration) error {
-	encoded, err := encodeValue(value)
+func (c *controlPlane) kvPut(ctx context.Context, bucket, key string, value any, ttl time.Duration) (err error) {
+	_, endSpan := c.telemetry.controlSpan(ctx, "bridge.control.kv_put")
+	defer func() { endSpan(err) }()
+
+	encoded, err := encodeValue(value, c.codecs)
 	if err != nil {
 		return err
 	}
@@ -215,22 +435,26 @@ func (c *controlPlane) kvPut(bucket, key string, value any, ttl time.Duration) e
 				TtlSec: int64(ttl.Seconds()),
 			},
 		},
-	}, true, 5*time.Second)
+	}, true, true, 5*time.Second)
 	if err != nil {
 		return err
 	}
 
 	res := resp.GetKvCommonRes()
 	if res == nil {
-		return errors.New("kv_put: empty response")
+		err = errors.New("kv_put: empty response")
+		return err
 	}
 	if st := res.GetStatus(); st.GetCode() != 0 {
-		return statusError(st)
+		err = statusError(st)
 	}
-	return nil
+	return err
 }
 
-func (c *controlPlane) kvDel(bucket, key string) error {
+func (c *controlPlane) kvDel(ctx context.Context, bucket, key string) (err error) {
+	_, endSpan := c.telemetry.controlSpan(ctx, "bridge.control.kv_del")
+	defer func() { endSpan(err) }()
+
 	resp, err := c.send(&pb.Control{
 		OneofMsg: &pb.Control_KvDel{
 			KvDel: &pb.KVDelReq{
@@ -238,19 +462,20 @@ func (c *controlPlane) kvDel(bucket, key string) error {
 				Key:    key,
 			},
 		},
-	}, true, 5*time.Second)
+	}, true, true, 5*time.Second)
 	if err != nil {
 		return err
 	}
 
 	res := resp.GetKvCommonRes()
 	if res == nil {
-		return errors.New("kv_del: empty response")
+		err = errors.New("kv_del: empty response")
+		return err
 	}
 	if st := res.GetStatus(); st.GetCode() != 0 {
-		return statusError(st)
+		err = statusError(st)
 	}
-	return nil
+	return err
 }
 
 func statusError(st *pb.Status) error {
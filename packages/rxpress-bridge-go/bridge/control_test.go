@@ -0,0 +1,200 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+
+	pb "github.com/newintel/rxpress-bridge-go/internal/pb/proto"
+)
+
+// fakeStream is a minimal in-memory pb.ControlPlane_ConnectClient, letting tests drive
+// controlPlane's reconnect/replay state machine without a real control-plane server.
+type fakeStream struct {
+	grpc.ClientStream
+
+	recvCh chan *pb.Control
+	once   sync.Once
+
+	mu      sync.Mutex
+	sent    []*pb.Control
+	sendErr error
+}
+
+func newFakeStream() *fakeStream {
+	return &fakeStream{recvCh: make(chan *pb.Control, 16)}
+}
+
+func (f *fakeStream) Send(c *pb.Control) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sent = append(f.sent, c)
+	return nil
+}
+
+func (f *fakeStream) Recv() (*pb.Control, error) {
+	msg, ok := <-f.recvCh
+	if !ok {
+		return nil, errors.New("fakeStream: closed")
+	}
+	return msg, nil
+}
+
+func (f *fakeStream) pushReply(c *pb.Control) {
+	f.recvCh <- c
+}
+
+// breakStream simulates the stream dropping: any blocked Recv returns an error and the
+// stream stops accepting further reads. Safe to call more than once.
+func (f *fakeStream) breakStream() {
+	f.once.Do(func() { close(f.recvCh) })
+}
+
+func (f *fakeStream) sentMessages() []*pb.Control {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]*pb.Control, len(f.sent))
+	copy(out, f.sent)
+	return out
+}
+
+// sequenceDialer returns a controlDialer that hands out streams in order, one per call,
+// failing once the sequence is exhausted.
+func sequenceDialer(streams ...*fakeStream) controlDialer {
+	next := 0
+	return func(ctx context.Context) (*grpc.ClientConn, pb.ControlPlane_ConnectClient, error) {
+		if next >= len(streams) {
+			return nil, nil, errors.New("sequenceDialer: exhausted")
+		}
+		s := streams[next]
+		next++
+		return nil, s, nil
+	}
+}
+
+func newTestControlPlane(t *testing.T, dial controlDialer, streams ...*fakeStream) *controlPlane {
+	t.Helper()
+	telemetry, err := newTelemetry(nil)
+	if err != nil {
+		t.Fatalf("newTelemetry: %v", err)
+	}
+	ready := newReadinessMonitor(health.NewServer())
+	backoff := ControlBackoff{Base: time.Millisecond, Max: 5 * time.Millisecond}
+
+	cp, err := newControlPlane(context.Background(), dial, newCodecRegistry(nil), backoff, ready, telemetry)
+	if err != nil {
+		t.Fatalf("newControlPlane: %v", err)
+	}
+	t.Cleanup(func() {
+		// recvLoop blocks on the active fakeStream's Recv with no regard for baseCtx,
+		// same as a real gRPC stream blocking until its connection dies - break every
+		// stream so Close()'s wg.Wait() doesn't hang the test.
+		for _, s := range streams {
+			s.breakStream()
+		}
+		cp.Close()
+	})
+	return cp
+}
+
+// TestControlPlane_ReconnectReplaysIdempotentCall simulates the stream dropping while a
+// kvGet is in flight: the call must survive the disconnect, its request must be resent
+// once a new stream is dialed, and it must resolve once the new stream answers it.
+func TestControlPlane_ReconnectReplaysIdempotentCall(t *testing.T) {
+	stream1 := newFakeStream()
+	stream2 := newFakeStream()
+	cp := newTestControlPlane(t, sequenceDialer(stream1, stream2), stream1, stream2)
+
+	type result struct {
+		value any
+		err   error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		value, err := cp.kvGet(context.Background(), "bucket", "key")
+		resultCh <- result{value, err}
+	}()
+
+	// Wait for the request to land on the first stream, then break it before it's
+	// answered - the call must be retried, not failed, because kvGet is idempotent.
+	waitForSend(t, stream1, 1)
+	stream1.breakStream()
+
+	// Once reconnected, the controlPlane must resend the same request (same
+	// correlation) on the new stream.
+	waitForSend(t, stream2, 1)
+	replayed := stream2.sentMessages()[0]
+
+	stream2.pushReply(&pb.Control{
+		Correlation: replayed.GetCorrelation(),
+		OneofMsg: &pb.Control_KvGetRes{
+			KvGetRes: &pb.KVGetRes{
+				Status: &pb.Status{Code: 0},
+				Value:  &pb.Value{V: &pb.Value_S{S: "hello"}},
+			},
+		},
+	})
+
+	select {
+	case res := <-resultCh:
+		if res.err != nil {
+			t.Fatalf("kvGet: %v", res.err)
+		}
+		if res.value != "hello" {
+			t.Fatalf("kvGet value = %v, want %q", res.value, "hello")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("kvGet did not resolve after replay")
+	}
+
+	if cp.State() != ControlConnected {
+		t.Fatalf("State() = %v, want ControlConnected after successful reconnect", cp.State())
+	}
+}
+
+// TestControlPlane_DisconnectFailsNonIdempotentCall checks the other half of the same
+// contract: a non-idempotent call (emit) must fail immediately with
+// ErrControlDisconnected rather than being replayed, since the control plane might have
+// already applied it once.
+func TestControlPlane_DisconnectFailsNonIdempotentCall(t *testing.T) {
+	stream1 := newFakeStream()
+	stream2 := newFakeStream()
+	cp := newTestControlPlane(t, sequenceDialer(stream1, stream2), stream1, stream2)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cp.emit(context.Background(), nil, "topic", nil)
+	}()
+
+	waitForSend(t, stream1, 1)
+	stream1.breakStream()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, ErrControlDisconnected) {
+			t.Fatalf("emit error = %v, want ErrControlDisconnected", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("emit did not fail after disconnect")
+	}
+}
+
+func waitForSend(t *testing.T, stream *fakeStream, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(stream.sentMessages()) >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d message(s) to be sent", n)
+}
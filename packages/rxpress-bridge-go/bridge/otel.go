@@ -0,0 +1,121 @@
+package bridge
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/rxpress/rxpress-bridge-go/bridge"
+
+// telemetry holds the tracer, meter and metric instruments shared by a server and its
+// controlPlane. It is always non-nil and falls back to the OTel no-op implementations
+// when the caller doesn't configure a TracerProvider/MeterProvider, so call sites never
+// need a nil check.
+//
+// This intentionally does not include a KV cache hit-rate metric: the control plane's
+// kvGet always round-trips to the control plane (see control.go) - there is no caching
+// layer in this bridge to back a hit rate, so that metric is descoped here rather than
+// shipped as a counter that can never move, mirroring the SPIFFE-verification descope
+// note on ServeOptions.RequireClientCert.
+type telemetry struct {
+	tracer trace.Tracer
+	meter  metric.Meter
+
+	invocationCount   metric.Int64Counter
+	invocationLatency metric.Float64Histogram
+	controlRTT        metric.Float64Histogram
+}
+
+func newTelemetry(opts *ServeOptions) (*telemetry, error) {
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	if opts != nil {
+		if opts.TracerProvider != nil {
+			tracerProvider = opts.TracerProvider
+		}
+		if opts.MeterProvider != nil {
+			meterProvider = opts.MeterProvider
+		}
+	}
+
+	meter := meterProvider.Meter(instrumentationName)
+
+	invocationCount, err := meter.Int64Counter(
+		"bridge.invocation.count",
+		metric.WithDescription("Number of Invoke/InvokeStream calls handled, by handler and status"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	invocationLatency, err := meter.Float64Histogram(
+		"bridge.invocation.latency",
+		metric.WithDescription("Invoke/InvokeStream handler latency"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	controlRTT, err := meter.Float64Histogram(
+		"bridge.control.rtt",
+		metric.WithDescription("Round-trip latency of control-plane RPCs (log, emit, kv_*)"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &telemetry{
+		tracer:            tracerProvider.Tracer(instrumentationName),
+		meter:             meter,
+		invocationCount:   invocationCount,
+		invocationLatency: invocationLatency,
+		controlRTT:        controlRTT,
+	}, nil
+}
+
+// recordInvocation starts a span named "bridge.invoke/{handler}" tagged with the
+// tenant, handler name and eventual status, and returns a func to call when the
+// invocation finishes with its final status.
+func (t *telemetry) recordInvocation(ctx context.Context, handlerName, tenant string) (context.Context, func(status string)) {
+	start := time.Now()
+	ctx, span := t.tracer.Start(ctx, "bridge.invoke/"+handlerName, trace.WithAttributes(
+		attribute.String("bridge.handler", handlerName),
+		attribute.String("bridge.tenant", tenant),
+	))
+
+	return ctx, func(status string) {
+		span.SetAttributes(attribute.String("bridge.status", status))
+		span.End()
+
+		attrs := metric.WithAttributes(
+			attribute.String("bridge.handler", handlerName),
+			attribute.String("bridge.status", status),
+		)
+		t.invocationCount.Add(ctx, 1, attrs)
+		t.invocationLatency.Record(ctx, float64(time.Since(start).Milliseconds()), attrs)
+	}
+}
+
+// controlSpan starts a span around a single control-plane RPC and records its
+// round-trip latency once the returned func is called with the resulting error.
+func (t *telemetry) controlSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+	start := time.Now()
+	ctx, span := t.tracer.Start(ctx, name)
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		t.controlRTT.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(
+			attribute.String("bridge.control.op", name),
+		))
+	}
+}
@@ -2,22 +2,72 @@ package bridge
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/newintel/rxpress-bridge-go/internal/pb/proto"
 )
 
+// dialTimeout bounds the initial control-plane dial so Serve can never hang forever
+// waiting on a gRPC connection that will never come up.
+const dialTimeout = 10 * time.Second
+
+// handlerContext wraps ctx with a deadline derived from the invocation's Meta, if any,
+// so handlers abort deterministically once the caller's own deadline expires. The
+// returned cancel func must always be called by the caller, even when no deadline was
+// applied.
+func handlerContext(ctx context.Context, meta *pb.Meta) (context.Context, context.CancelFunc) {
+	if meta == nil {
+		return context.WithCancel(ctx)
+	}
+	if ms := meta.GetCancelAfterMs(); ms > 0 {
+		return context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+	}
+	if ms := meta.GetDeadlineMs(); ms > 0 {
+		return context.WithDeadline(ctx, time.UnixMilli(ms))
+	}
+	return context.WithCancel(ctx)
+}
+
 type Handler func(ctx context.Context, method string, input map[string]any, meta map[string]any, bridge *Context) (map[string]any, error)
 
+// StreamChunk is one frame of a streaming invocation's output. Handlers push chunks
+// until they close the channel returned from a StreamHandler; the last chunk pushed
+// should normally set Final so the caller can stop waiting on more frames.
+type StreamChunk struct {
+	Output map[string]any
+	Meta   map[string]any
+	Final  bool
+}
+
+// StreamHandler is the streaming counterpart to Handler: instead of a single
+// InvokeResponse it returns a channel of StreamChunk, letting bridges push partial
+// output or progress (LLM tokens, log tails, upload progress) as it becomes available.
+// Handlers must close the channel when done and respect ctx.Done() for cancellation.
+type StreamHandler func(ctx context.Context, method string, input map[string]any, meta map[string]any, bridge *Context) (<-chan StreamChunk, error)
+
 type server struct {
 	pb.UnimplementedInvokerServer
 
-	handlers map[string]Handler
-	control  *controlPlane
+	handlers         map[string]Handler
+	streamHandlers   map[string]StreamHandler
+	control          *controlPlane
+	codecs           *codecRegistry
+	throttle         *throttle
+	handlerThrottles handlerThrottles
+	telemetry        *telemetry
 }
 
 func (s *server) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb.InvokeResponse, error) {
@@ -34,12 +84,36 @@ func (s *server) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb.InvokeR
 		return resp, nil
 	}
 
+	if !s.throttle.acquire(ctx) {
+		resp.Status = &pb.Status{
+			Code:    int32(codes.ResourceExhausted),
+			Message: "bridge: too many concurrent invocations",
+		}
+		return resp, nil
+	}
+	defer s.throttle.release()
+
+	if !s.handlerThrottles.acquire(ctx, req.GetHandlerName()) {
+		resp.Status = &pb.Status{
+			Code:    int32(codes.ResourceExhausted),
+			Message: fmt.Sprintf("bridge: too many concurrent invocations of %s", req.GetHandlerName()),
+		}
+		return resp, nil
+	}
+	defer s.handlerThrottles.release(req.GetHandlerName())
+
 	meta := fromProtoMeta(req.GetMeta())
-	bridgeCtx := newContext(s.control, meta)
+
+	tenant, _ := meta["tenant"].(string)
+	invokeCtx, endSpan := s.telemetry.recordInvocation(ctx, req.GetHandlerName(), tenant)
+	outcome := "error"
+	defer func() { endSpan(outcome) }()
+
+	bridgeCtx := newContext(invokeCtx, s.control, meta)
 
 	input := make(map[string]any, len(req.GetInput()))
 	for key, value := range req.GetInput() {
-		decoded, err := decodeValue(value)
+		decoded, err := decodeValue(value, s.codecs)
 		if err != nil {
 			resp.Status = &pb.Status{
 				Code:    1,
@@ -50,7 +124,10 @@ func (s *server) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb.InvokeR
 		input[key] = decoded
 	}
 
-	output, err := handler(ctx, req.GetMethod(), input, meta, bridgeCtx)
+	deadlineCtx, cancel := handlerContext(invokeCtx, req.GetMeta())
+	defer cancel()
+
+	output, err := handler(deadlineCtx, req.GetMethod(), input, meta, bridgeCtx)
 	if err != nil {
 		resp.Status = &pb.Status{
 			Code:    1,
@@ -59,7 +136,7 @@ func (s *server) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb.InvokeR
 		return resp, nil
 	}
 
-	result, err := encodeMap(output)
+	result, err := encodeMap(output, s.codecs)
 	if err != nil {
 		resp.Status = &pb.Status{
 			Code:    1,
@@ -68,16 +145,89 @@ func (s *server) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb.InvokeR
 		return resp, nil
 	}
 
+	outcome = "ok"
 	resp.Status = &pb.Status{Code: 0}
 	resp.Output = result
 	return resp, nil
 }
 
+func (s *server) InvokeStream(req *pb.InvokeRequest, stream pb.Invoker_InvokeStreamServer) error {
+	handler, ok := s.streamHandlers[req.GetHandlerName()]
+	if !ok {
+		return status.Errorf(codes.NotFound, "stream handler not found: %s", req.GetHandlerName())
+	}
+
+	if !s.throttle.acquire(stream.Context()) {
+		return status.Error(codes.ResourceExhausted, "bridge: too many concurrent invocations")
+	}
+	defer s.throttle.release()
+
+	if !s.handlerThrottles.acquire(stream.Context(), req.GetHandlerName()) {
+		return status.Errorf(codes.ResourceExhausted, "bridge: too many concurrent invocations of %s", req.GetHandlerName())
+	}
+	defer s.handlerThrottles.release(req.GetHandlerName())
+
+	meta := fromProtoMeta(req.GetMeta())
+
+	tenant, _ := meta["tenant"].(string)
+	invokeCtx, endSpan := s.telemetry.recordInvocation(stream.Context(), req.GetHandlerName(), tenant)
+	outcome := "error"
+	defer func() { endSpan(outcome) }()
+
+	bridgeCtx := newContext(invokeCtx, s.control, meta)
+
+	input := make(map[string]any, len(req.GetInput()))
+	for key, value := range req.GetInput() {
+		decoded, err := decodeValue(value, s.codecs)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "decode input %s failed: %v", key, err)
+		}
+		input[key] = decoded
+	}
+
+	streamCtx, cancel := handlerContext(invokeCtx, req.GetMeta())
+	defer cancel()
+
+	chunks, err := handler(streamCtx, req.GetMethod(), input, meta, bridgeCtx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-streamCtx.Done():
+			return streamCtx.Err()
+		case chunk, ok := <-chunks:
+			if !ok {
+				outcome = "ok"
+				return nil
+			}
+			output, err := encodeMap(chunk.Output, s.codecs)
+			if err != nil {
+				return status.Errorf(codes.Internal, "encode stream output failed: %v", err)
+			}
+			frame := &pb.InvokeStreamChunk{
+				Correlation: req.GetCorrelation(),
+				Output:      output,
+				Meta:        toProtoMeta(chunk.Meta),
+				Final:       chunk.Final,
+			}
+			if err := stream.Send(frame); err != nil {
+				return err
+			}
+			if chunk.Final {
+				outcome = "ok"
+				return nil
+			}
+		}
+	}
+}
+
 type App struct {
 	server  *grpc.Server
 	control *controlPlane
-	conn    *grpc.ClientConn
 	errCh   chan error
+	health  *health.Server
 }
 
 func (a *App) Wait() error {
@@ -94,17 +244,68 @@ func (a *App) Stop() {
 	if a == nil {
 		return
 	}
+	a.health.SetServingStatus(invokerService, healthpb.HealthCheckResponse_NOT_SERVING)
 	a.control.Close()
 	a.server.GracefulStop()
-	_ = a.conn.Close()
 }
 
 type ServeOptions struct {
-	ServerOptions []grpc.ServerOption
+	ServerOptions  []grpc.ServerOption
+	StreamHandlers map[string]StreamHandler
+
+	// TLSConfig, set on the Invoker listener, takes precedence over the BRIDGE_TLS_*
+	// environment variables.
+	TLSConfig *tls.Config
+	// ClientTLSConfig, used when dialing the control plane, takes precedence over the
+	// CONTROL_TLS_* environment variables.
+	ClientTLSConfig *tls.Config
+	// RequireClientCert enables mTLS on the Invoker listener, requiring and verifying a
+	// client certificate against BRIDGE_TLS_CA (or TLSConfig.ClientCAs, if set).
+	//
+	// SPIFFE-style peer identity verification (matching the client cert's SPIFFE ID
+	// against an allowlist, rather than just chain validation) is not implemented here;
+	// it's descoped from this change. A bridge that needs it today can set
+	// TLSConfig.VerifyPeerCertificate itself.
+	RequireClientCert bool
+
+	// Codecs are tried, in order, before the built-in codecs whenever a value is
+	// encoded or decoded, so a bridge can customise or extend the typed wire
+	// representation used for domain types such as big.Int, time.Time or uuid.UUID.
+	Codecs []Codec
+
+	// MaxConcurrentInvocations caps how many Invoke/InvokeStream calls run at once; 0
+	// (the default) leaves concurrency unbounded. Requests beyond the cap wait for a
+	// free slot, up to QueueDepth, after which they fail with RESOURCE_EXHAUSTED.
+	MaxConcurrentInvocations int
+	// QueueDepth bounds how many requests may wait for a slot once
+	// MaxConcurrentInvocations is reached. Ignored when MaxConcurrentInvocations is 0.
+	QueueDepth int
+	// HandlerConcurrency caps concurrent invocations per handler name, independent of
+	// MaxConcurrentInvocations, so one handler can't consume the whole global budget and
+	// starve the others. Handlers absent from the map (or with a value <= 0) are
+	// unbounded aside from the global cap. Calls beyond a handler's cap fail immediately
+	// with RESOURCE_EXHAUSTED rather than queueing.
+	HandlerConcurrency map[string]int
+
+	// ControlBackoff configures the control plane's reconnect backoff. Zero value
+	// fields fall back to ControlBackoff's own defaults.
+	ControlBackoff ControlBackoff
+
+	// TracerProvider and MeterProvider configure OTel instrumentation for the Invoker
+	// listener and control-plane client. Both default to the OTel global providers
+	// (otel.GetTracerProvider/otel.GetMeterProvider) when left nil, so wiring an SDK
+	// provider via otel.SetTracerProvider/otel.SetMeterProvider also works without
+	// touching ServeOptions.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
 }
 
 func Serve(ctx context.Context, bind, controlTarget string, handlers map[string]Handler, opts *ServeOptions) (*App, error) {
-	if len(handlers) == 0 {
+	streamHandlers := map[string]StreamHandler{}
+	if opts != nil {
+		streamHandlers = opts.StreamHandlers
+	}
+	if len(handlers) == 0 && len(streamHandlers) == 0 {
 		return nil, fmt.Errorf("serve: at least one handler required")
 	}
 
@@ -113,33 +314,90 @@ func Serve(ctx context.Context, bind, controlTarget string, handlers map[string]
 		return nil, fmt.Errorf("listen %s: %w", bind, err)
 	}
 
-	conn, err := grpc.DialContext(ctx, controlTarget, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return nil, fmt.Errorf("dial control plane: %w", err)
+	dialCreds := insecure.NewCredentials()
+	if clientTLS, err := controlDialTLS(opts); err != nil {
+		return nil, err
+	} else if clientTLS != nil {
+		dialCreds = clientTLS
 	}
 
-	control, err := newControlPlane(ctx, conn)
+	dial := func(dialCtx context.Context) (*grpc.ClientConn, pb.ControlPlane_ConnectClient, error) {
+		timeoutCtx, cancel := context.WithTimeout(dialCtx, dialTimeout)
+		defer cancel()
+
+		conn, err := grpc.DialContext(timeoutCtx, controlTarget,
+			grpc.WithTransportCredentials(dialCreds),
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("dial control plane: %w", err)
+		}
+
+		stream, err := pb.NewControlPlaneClient(conn).Connect(dialCtx)
+		if err != nil {
+			_ = conn.Close()
+			return nil, nil, fmt.Errorf("control connect: %w", err)
+		}
+		return conn, stream, nil
+	}
+
+	var customCodecs []Codec
+	var controlBackoff ControlBackoff
+	if opts != nil {
+		customCodecs = opts.Codecs
+		controlBackoff = opts.ControlBackoff
+	}
+	codecs := newCodecRegistry(customCodecs)
+
+	telemetry, err := newTelemetry(opts)
 	if err != nil {
-		_ = conn.Close()
-		return nil, err
+		return nil, fmt.Errorf("init telemetry: %w", err)
 	}
 
-	serverOpts := []grpc.ServerOption{}
+	serverOpts := []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}
 	if opts != nil && len(opts.ServerOptions) > 0 {
 		serverOpts = append(serverOpts, opts.ServerOptions...)
 	}
+	if serverTLSCreds, err := serverTLS(opts); err != nil {
+		return nil, err
+	} else if serverTLSCreds != nil {
+		serverOpts = append(serverOpts, grpc.Creds(serverTLSCreds))
+	}
 
 	s := grpc.NewServer(serverOpts...)
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(invokerService, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthSrv)
+	ready := newReadinessMonitor(healthSrv)
+
+	control, err := newControlPlane(ctx, dial, codecs, controlBackoff, ready, telemetry)
+	if err != nil {
+		return nil, err
+	}
+
 	app := &App{
 		server:  s,
 		control: control,
-		conn:    conn,
 		errCh:   make(chan error, 1),
+		health:  healthSrv,
+	}
+
+	var maxConcurrent, queueDepth int
+	var handlerConcurrency map[string]int
+	if opts != nil {
+		maxConcurrent = opts.MaxConcurrentInvocations
+		queueDepth = opts.QueueDepth
+		handlerConcurrency = opts.HandlerConcurrency
 	}
 
 	pb.RegisterInvokerServer(s, &server{
-		handlers: handlers,
-		control:  control,
+		handlers:         handlers,
+		streamHandlers:   streamHandlers,
+		control:          control,
+		codecs:           codecs,
+		throttle:         newThrottle(maxConcurrent, queueDepth, ready),
+		handlerThrottles: newHandlerThrottles(handlerConcurrency),
+		telemetry:        telemetry,
 	})
 
 	go func() {
@@ -0,0 +1,168 @@
+package bridge
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// invokerService is the service name reported by the health server; orchestrators poll
+// it to learn whether the bridge can accept more Invoke/InvokeStream traffic.
+const invokerService = "bridge.Invoker"
+
+// readinessMonitor combines every signal that determines whether the bridge is ready
+// to accept traffic - currently queue saturation and control-plane connectivity - into
+// a single SERVING/NOT_SERVING status on the gRPC health service.
+type readinessMonitor struct {
+	server *health.Server
+
+	mu        sync.Mutex
+	queueUp   bool
+	controlUp bool
+}
+
+func newReadinessMonitor(server *health.Server) *readinessMonitor {
+	return &readinessMonitor{server: server, queueUp: true, controlUp: true}
+}
+
+func (r *readinessMonitor) setQueue(up bool) {
+	r.mu.Lock()
+	r.queueUp = up
+	r.mu.Unlock()
+	r.publish()
+}
+
+func (r *readinessMonitor) setControl(up bool) {
+	r.mu.Lock()
+	r.controlUp = up
+	r.mu.Unlock()
+	r.publish()
+}
+
+func (r *readinessMonitor) publish() {
+	r.mu.Lock()
+	status := healthpb.HealthCheckResponse_SERVING
+	if !r.queueUp || !r.controlUp {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	r.mu.Unlock()
+	r.server.SetServingStatus(invokerService, status)
+}
+
+// throttle bounds how many invocations run concurrently and how many more may queue
+// waiting for a slot, rejecting the rest with RESOURCE_EXHAUSTED so callers can back off
+// instead of piling up unbounded goroutines.
+type throttle struct {
+	sem        chan struct{}
+	queueDepth int32
+	queued     int32
+	ready      *readinessMonitor
+}
+
+// newThrottle returns nil when maxConcurrent is 0, disabling backpressure entirely -
+// every invocation runs immediately, matching the server's historical behaviour.
+func newThrottle(maxConcurrent, queueDepth int, ready *readinessMonitor) *throttle {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	return &throttle{
+		sem:        make(chan struct{}, maxConcurrent),
+		queueDepth: int32(queueDepth),
+		ready:      ready,
+	}
+}
+
+// acquire blocks until a slot is free, ctx is done, or the queue is already full, in
+// which case it returns false immediately.
+func (t *throttle) acquire(ctx context.Context) bool {
+	if t == nil {
+		return true
+	}
+
+	select {
+	case t.sem <- struct{}{}:
+		return true
+	default:
+	}
+
+	if atomic.AddInt32(&t.queued, 1) > t.queueDepth {
+		atomic.AddInt32(&t.queued, -1)
+		return false
+	}
+	defer func() {
+		atomic.AddInt32(&t.queued, -1)
+		t.reportHealth()
+	}()
+	t.reportHealth()
+
+	select {
+	case t.sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (t *throttle) release() {
+	if t == nil {
+		return
+	}
+	<-t.sem
+	t.reportHealth()
+}
+
+func (t *throttle) saturated() bool {
+	if t == nil {
+		return false
+	}
+	return len(t.sem) == cap(t.sem) && atomic.LoadInt32(&t.queued) >= t.queueDepth
+}
+
+func (t *throttle) reportHealth() {
+	if t == nil || t.ready == nil {
+		return
+	}
+	t.ready.setQueue(!t.saturated())
+}
+
+// handlerThrottles enforces a separate concurrency cap per handler name, on top of the
+// server-wide throttle, so one noisy handler can't consume the whole global budget and
+// starve the others. Each handler gets its own throttle with no queueing (queueDepth 0):
+// once its cap is reached, further calls to that handler are rejected immediately rather
+// than waiting behind calls the global throttle has already admitted.
+type handlerThrottles map[string]*throttle
+
+// newHandlerThrottles returns nil when limits is empty, disabling per-handler
+// concurrency limiting entirely.
+func newHandlerThrottles(limits map[string]int) handlerThrottles {
+	if len(limits) == 0 {
+		return nil
+	}
+	throttles := make(handlerThrottles, len(limits))
+	for name, max := range limits {
+		if t := newThrottle(max, 0, nil); t != nil {
+			throttles[name] = t
+		}
+	}
+	if len(throttles) == 0 {
+		return nil
+	}
+	return throttles
+}
+
+func (h handlerThrottles) acquire(ctx context.Context, handlerName string) bool {
+	if h == nil {
+		return true
+	}
+	return h[handlerName].acquire(ctx)
+}
+
+func (h handlerThrottles) release(handlerName string) {
+	if h == nil {
+		return
+	}
+	h[handlerName].release()
+}
@@ -0,0 +1,136 @@
+package bridge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestThrottle_Nil_NeverBlocks(t *testing.T) {
+	var th *throttle
+	if !th.acquire(context.Background()) {
+		t.Fatal("nil throttle must not block or reject")
+	}
+	th.release() // must not panic
+}
+
+// TestThrottle_RejectsOnceQueueIsFull exercises the RESOURCE_EXHAUSTED path: once
+// MaxConcurrentInvocations slots are taken and QueueDepth waiters are already queued,
+// acquire must return false immediately instead of blocking indefinitely.
+func TestThrottle_RejectsOnceQueueIsFull(t *testing.T) {
+	ready := newReadinessMonitor(health.NewServer())
+	th := newThrottle(1, 1, ready)
+
+	if !th.acquire(context.Background()) {
+		t.Fatal("first acquire should succeed immediately (slot free)")
+	}
+
+	// A second, blocked acquire occupies the one allowed queue slot.
+	blockedDone := make(chan bool, 1)
+	blockedCtx, cancelBlocked := context.WithCancel(context.Background())
+	defer cancelBlocked()
+	go func() { blockedDone <- th.acquire(blockedCtx) }()
+
+	// Give the blocked goroutine time to register itself in the queue.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if th.saturated() {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !th.saturated() {
+		t.Fatal("throttle did not report saturated with 1 running + 1 queued against MaxConcurrentInvocations=1, QueueDepth=1")
+	}
+
+	// A third caller arrives after both the single slot and the single queue slot are
+	// taken; it must be rejected outright, not block.
+	rejectCtx, cancelReject := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancelReject()
+	if th.acquire(rejectCtx) {
+		t.Fatal("acquire should have been rejected with queue full; it should never have reached ctx timeout or a free slot")
+	}
+	if err := rejectCtx.Err(); err != nil {
+		t.Fatal("acquire blocked until ctx timeout instead of rejecting immediately")
+	}
+
+	cancelBlocked()
+	if ok := <-blockedDone; ok {
+		t.Fatal("blocked acquire should fail once its own ctx is cancelled")
+	}
+
+	th.release()
+}
+
+// TestReadinessMonitor_PublishesServingOnlyWhenBothUp checks the combination logic that
+// both throttle and controlPlane rely on: SERVING requires the queue AND the control
+// plane to both be up, and either going down flips the health service to NOT_SERVING.
+func TestReadinessMonitor_PublishesServingOnlyWhenBothUp(t *testing.T) {
+	healthSrv := health.NewServer()
+	ready := newReadinessMonitor(healthSrv)
+
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := healthSrv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: invokerService})
+		if err != nil {
+			t.Fatalf("health Check: %v", err)
+		}
+		return resp.Status
+	}
+
+	// newReadinessMonitor starts both signals up, but doesn't publish until a setter is
+	// called; trigger one to get an initial reading.
+	ready.setQueue(true)
+	if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("status = %v, want SERVING when both signals are up", got)
+	}
+
+	ready.setQueue(false)
+	if got := check(); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("status = %v, want NOT_SERVING when the queue is saturated", got)
+	}
+
+	ready.setQueue(true)
+	ready.setControl(false)
+	if got := check(); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("status = %v, want NOT_SERVING when the control plane is disconnected", got)
+	}
+
+	ready.setControl(true)
+	if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("status = %v, want SERVING once both signals are up again", got)
+	}
+}
+
+func TestHandlerThrottles_PerHandlerCapIsIndependent(t *testing.T) {
+	throttles := newHandlerThrottles(map[string]int{"a": 1})
+
+	if !throttles.acquire(context.Background(), "a") {
+		t.Fatal("first acquire for handler \"a\" should succeed")
+	}
+
+	rejectCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if throttles.acquire(rejectCtx, "a") {
+		t.Fatal("second concurrent acquire for handler \"a\" should be rejected (cap is 1)")
+	}
+
+	// A handler with no configured limit is unaffected by "a"'s cap.
+	if !throttles.acquire(context.Background(), "b") {
+		t.Fatal("handler \"b\" has no configured cap and should never be rejected")
+	}
+
+	throttles.release("a")
+	throttles.release("b")
+}
+
+func TestHandlerThrottles_Nil_NeverBlocks(t *testing.T) {
+	var throttles handlerThrottles
+	if !throttles.acquire(context.Background(), "anything") {
+		t.Fatal("nil handlerThrottles must not block or reject")
+	}
+	throttles.release("anything") // must not panic
+}
@@ -0,0 +1,102 @@
+package bridge
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// serverTLS builds transport credentials for the Invoker listener, preferring
+// opts.TLSConfig and falling back to the BRIDGE_TLS_CERT/BRIDGE_TLS_KEY/BRIDGE_TLS_CA
+// environment variables. It returns nil, nil when no TLS material is configured, in
+// which case Serve falls back to an insecure listener.
+func serverTLS(opts *ServeOptions) (credentials.TransportCredentials, error) {
+	var cfg *tls.Config
+
+	if opts != nil && opts.TLSConfig != nil {
+		cfg = opts.TLSConfig.Clone()
+	} else {
+		certFile := os.Getenv("BRIDGE_TLS_CERT")
+		keyFile := os.Getenv("BRIDGE_TLS_KEY")
+		if certFile == "" || keyFile == "" {
+			return nil, nil
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load bridge TLS keypair: %w", err)
+		}
+		cfg = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	// RequireClientCert must be enforced regardless of whether cfg came from
+	// opts.TLSConfig or the env-var fallback - otherwise a caller-supplied TLSConfig
+	// silently skips mTLS even though it asked for it.
+	if opts != nil && opts.RequireClientCert {
+		pool := cfg.ClientCAs
+		if pool == nil {
+			var err error
+			pool, err = loadCAPool(os.Getenv("BRIDGE_TLS_CA"))
+			if err != nil {
+				return nil, err
+			}
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+// controlDialTLS builds transport credentials for dialing the control plane,
+// preferring opts.ClientTLSConfig and falling back to the CONTROL_TLS_CERT/
+// CONTROL_TLS_KEY/CONTROL_TLS_CA environment variables. It returns nil, nil when no TLS
+// material is configured, in which case Serve dials insecurely.
+func controlDialTLS(opts *ServeOptions) (credentials.TransportCredentials, error) {
+	if opts != nil && opts.ClientTLSConfig != nil {
+		return credentials.NewTLS(opts.ClientTLSConfig), nil
+	}
+
+	certFile := os.Getenv("CONTROL_TLS_CERT")
+	keyFile := os.Getenv("CONTROL_TLS_KEY")
+	caFile := os.Getenv("CONTROL_TLS_CA")
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{}
+	if certFile != "" && keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load control TLS keypair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pool, err := loadCAPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+func loadCAPool(caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		return nil, fmt.Errorf("missing CA file for client certificate verification")
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("parse CA file %s: no certificates found", caFile)
+	}
+	return pool, nil
+}
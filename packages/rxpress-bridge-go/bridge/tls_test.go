@@ -0,0 +1,263 @@
+package bridge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// generateCert issues a self-signed (parent nil) or CA-signed test certificate and
+// returns its PEM-encoded cert/key alongside the parsed certificate and key, so callers
+// can use it to sign further certificates (e.g. a CA signing a server leaf cert).
+func generateCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (certPEM, keyPEM []byte, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{cn},
+	}
+	if isCA {
+		template.IsCA = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		template.BasicConstraintsValid = true
+	}
+
+	signer, signerKey := parent, parentKey
+	if signer == nil {
+		signer, signerKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, parsed, key
+}
+
+func writeTemp(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+// handshake drives serverCreds.ServerHandshake against an in-process client configured
+// with clientCfg, returning whichever side's handshake failed first (if any).
+func handshake(t *testing.T, serverCreds credentials.TransportCredentials, clientCfg *tls.Config) error {
+	t.Helper()
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	serverErrCh := make(chan error, 1)
+	go func() {
+		_, _, err := serverCreds.ServerHandshake(serverConn)
+		serverErrCh <- err
+	}()
+
+	clientErr := tls.Client(clientConn, clientCfg).Handshake()
+	// net.Pipe has no buffering: keep draining the client end so the server's writes
+	// after the client's last flight (a rejection alert, TLS 1.3 session tickets) can
+	// complete instead of blocking the server handshake forever.
+	go io.Copy(io.Discard, clientConn)
+	serverErr := <-serverErrCh
+	if clientErr != nil {
+		return clientErr
+	}
+	return serverErr
+}
+
+func TestServerTLS_NoConfig(t *testing.T) {
+	t.Setenv("BRIDGE_TLS_CERT", "")
+	t.Setenv("BRIDGE_TLS_KEY", "")
+
+	creds, err := serverTLS(nil)
+	if err != nil || creds != nil {
+		t.Fatalf("serverTLS() = %v, %v; want nil, nil with no TLS material configured", creds, err)
+	}
+}
+
+func TestServerTLS_SelfSignedHandshake(t *testing.T) {
+	dir := t.TempDir()
+	caCertPEM, _, caCert, caKey := generateCert(t, "test-ca", nil, nil, true)
+	serverCertPEM, serverKeyPEM, _, _ := generateCert(t, "localhost", caCert, caKey, false)
+
+	t.Setenv("BRIDGE_TLS_CERT", writeTemp(t, dir, "server.crt", serverCertPEM))
+	t.Setenv("BRIDGE_TLS_KEY", writeTemp(t, dir, "server.key", serverKeyPEM))
+
+	serverCreds, err := serverTLS(&ServeOptions{})
+	if err != nil {
+		t.Fatalf("serverTLS: %v", err)
+	}
+	if serverCreds == nil {
+		t.Fatal("serverTLS returned nil credentials with BRIDGE_TLS_CERT/KEY set")
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCertPEM) {
+		t.Fatal("failed to build trust pool from self-signed CA cert")
+	}
+	clientCfg := &tls.Config{RootCAs: caPool, ServerName: "localhost"}
+
+	if err := handshake(t, serverCreds, clientCfg); err != nil {
+		t.Fatalf("handshake against a server cert trusted by the client: %v", err)
+	}
+}
+
+func TestServerTLS_RequireClientCert(t *testing.T) {
+	dir := t.TempDir()
+	caCertPEM, _, caCert, caKey := generateCert(t, "test-ca", nil, nil, true)
+	serverCertPEM, serverKeyPEM, _, _ := generateCert(t, "localhost", caCert, caKey, false)
+	clientCertPEM, clientKeyPEM, _, _ := generateCert(t, "test-client", caCert, caKey, false)
+
+	t.Setenv("BRIDGE_TLS_CERT", writeTemp(t, dir, "server.crt", serverCertPEM))
+	t.Setenv("BRIDGE_TLS_KEY", writeTemp(t, dir, "server.key", serverKeyPEM))
+	t.Setenv("BRIDGE_TLS_CA", writeTemp(t, dir, "ca.crt", caCertPEM))
+
+	serverCreds, err := serverTLS(&ServeOptions{RequireClientCert: true})
+	if err != nil {
+		t.Fatalf("serverTLS: %v", err)
+	}
+
+	caPool := x509.NewCertPool()
+	caPool.AppendCertsFromPEM(caCertPEM)
+
+	noCertCfg := &tls.Config{RootCAs: caPool, ServerName: "localhost"}
+	if err := handshake(t, serverCreds, noCertCfg); err == nil {
+		t.Fatal("handshake without a client cert succeeded; want rejection under RequireClientCert")
+	}
+
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatalf("load client keypair: %v", err)
+	}
+	withCertCfg := &tls.Config{RootCAs: caPool, ServerName: "localhost", Certificates: []tls.Certificate{clientCert}}
+	if err := handshake(t, serverCreds, withCertCfg); err != nil {
+		t.Fatalf("handshake with a CA-signed client cert: %v", err)
+	}
+}
+
+func TestServerTLS_RequireClientCert_WithDirectTLSConfig(t *testing.T) {
+	caCertPEM, _, caCert, caKey := generateCert(t, "test-ca", nil, nil, true)
+	serverCertPEM, serverKeyPEM, _, _ := generateCert(t, "localhost", caCert, caKey, false)
+	clientCertPEM, clientKeyPEM, _, _ := generateCert(t, "test-client", caCert, caKey, false)
+
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	if err != nil {
+		t.Fatalf("load server keypair: %v", err)
+	}
+
+	caPool := x509.NewCertPool()
+	caPool.AppendCertsFromPEM(caCertPEM)
+
+	// A caller-supplied TLSConfig (not the BRIDGE_TLS_* env-var path) with its own
+	// ClientCAs set; RequireClientCert must still be enforced against it.
+	opts := &ServeOptions{
+		TLSConfig:         &tls.Config{Certificates: []tls.Certificate{serverCert}, ClientCAs: caPool},
+		RequireClientCert: true,
+	}
+
+	serverCreds, err := serverTLS(opts)
+	if err != nil {
+		t.Fatalf("serverTLS: %v", err)
+	}
+
+	noCertCfg := &tls.Config{RootCAs: caPool, ServerName: "localhost"}
+	if err := handshake(t, serverCreds, noCertCfg); err == nil {
+		t.Fatal("handshake without a client cert succeeded with a direct TLSConfig; want rejection under RequireClientCert")
+	}
+
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatalf("load client keypair: %v", err)
+	}
+	withCertCfg := &tls.Config{RootCAs: caPool, ServerName: "localhost", Certificates: []tls.Certificate{clientCert}}
+	if err := handshake(t, serverCreds, withCertCfg); err != nil {
+		t.Fatalf("handshake with a CA-signed client cert against a direct TLSConfig: %v", err)
+	}
+
+	// opts.TLSConfig itself must be left untouched - serverTLS should clone before
+	// mutating ClientAuth/ClientCAs.
+	if opts.TLSConfig.ClientAuth == tls.RequireAndVerifyClientCert {
+		t.Fatal("serverTLS mutated the caller's TLSConfig in place")
+	}
+}
+
+func TestControlDialTLS_SelfSignedHandshake(t *testing.T) {
+	dir := t.TempDir()
+	caCertPEM, _, caCert, caKey := generateCert(t, "test-ca", nil, nil, true)
+	controlCertPEM, controlKeyPEM, _, _ := generateCert(t, "localhost", caCert, caKey, false)
+
+	t.Setenv("CONTROL_TLS_CERT", "")
+	t.Setenv("CONTROL_TLS_KEY", "")
+	t.Setenv("CONTROL_TLS_CA", writeTemp(t, dir, "ca.crt", caCertPEM))
+
+	clientCreds, err := controlDialTLS(&ServeOptions{})
+	if err != nil {
+		t.Fatalf("controlDialTLS: %v", err)
+	}
+	if clientCreds == nil {
+		t.Fatal("controlDialTLS returned nil credentials with CONTROL_TLS_CA set")
+	}
+
+	serverCert, err := tls.X509KeyPair(controlCertPEM, controlKeyPEM)
+	if err != nil {
+		t.Fatalf("load control server keypair: %v", err)
+	}
+	serverCfg := &tls.Config{Certificates: []tls.Certificate{serverCert}}
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	serverErrCh := make(chan error, 1)
+	go func() {
+		serverErrCh <- tls.Server(serverConn, serverCfg).Handshake()
+	}()
+
+	if _, _, err := clientCreds.ClientHandshake(context.Background(), "localhost", clientConn); err != nil {
+		t.Fatalf("ClientHandshake: %v", err)
+	}
+	if err := <-serverErrCh; err != nil {
+		t.Fatalf("control server handshake: %v", err)
+	}
+}
@@ -5,15 +5,26 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 
 	pb "github.com/newintel/rxpress-bridge-go/internal/pb/proto"
 )
 
 // encodeValue mirrors the behaviour of the rxpress value codec used in other bridges.
-func encodeValue(value any) (*pb.Value, error) {
+// codecs may be nil, in which case only the built-in JSON/primitive path below applies.
+func encodeValue(value any, codecs *codecRegistry) (*pb.Value, error) {
 	msg := &pb.Value{}
 
+	if codec, ok := codecs.forValue(value); ok {
+		payload, err := codec.Encode(value)
+		if err != nil {
+			return nil, fmt.Errorf("encodeValue: codec %s: %w", codec.Type(), err)
+		}
+		msg.V = &pb.Value_Typed{Typed: &pb.TypedValue{Type: codec.Type(), Payload: payload}}
+		return msg, nil
+	}
+
 	switch v := value.(type) {
 	case nil:
 		msg.V = &pb.Value_Json{Json: "null"}
@@ -34,7 +45,9 @@ func encodeValue(value any) (*pb.Value, error) {
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 			u := reflect.ValueOf(value).Uint()
 			if u > math.MaxInt64 {
-				return nil, fmt.Errorf("encodeValue: uint64 %d overflows int64", u)
+				// Too big for Value_I64; fall back to the big.Int codec instead of
+				// erroring so large native uints still round-trip losslessly.
+				return encodeValue(new(big.Int).SetUint64(u), codecs)
 			}
 			msg.V = &pb.Value_I64{I64: int64(u)}
 		case reflect.Float32, reflect.Float64:
@@ -62,12 +75,22 @@ func encodeValue(value any) (*pb.Value, error) {
 	return msg, nil
 }
 
-func decodeValue(message *pb.Value) (any, error) {
+func decodeValue(message *pb.Value, codecs *codecRegistry) (any, error) {
 	if message == nil {
 		return nil, nil
 	}
 
 	switch v := message.V.(type) {
+	case *pb.Value_Typed:
+		codec, ok := codecs.forType(v.Typed.GetType())
+		if !ok {
+			return nil, fmt.Errorf("decodeValue: no codec registered for type %q", v.Typed.GetType())
+		}
+		decoded, err := codec.Decode(v.Typed.GetPayload())
+		if err != nil {
+			return nil, fmt.Errorf("decodeValue: codec %s: %w", codec.Type(), err)
+		}
+		return decoded, nil
 	case *pb.Value_S:
 		return v.S, nil
 	case *pb.Value_I64: